@@ -1,7 +1,8 @@
 // t-req Go Client Example
 //
-// This example demonstrates how to interact with the t-req server from Go.
-// No special SDK required - just standard HTTP requests!
+// This example demonstrates how to interact with the t-req server from Go
+// using the pkg/treqclient SDK. See that package for the typed client,
+// functional options, and SSE subscription support.
 //
 // Start the server:
 //   treq serve
@@ -12,229 +13,28 @@
 package main
 
 import (
-	"bufio"
-	"bytes"
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"strings"
+
+	"github.com/tensorix-labs/t-req/pkg/treqclient"
 )
 
 const baseURL = "http://127.0.0.1:4096"
 
-// Types for API responses
-
-type HealthResponse struct {
-	Healthy bool   `json:"healthy"`
-	Version string `json:"version"`
-}
-
-type ParsedRequestInfo struct {
-	Index       int               `json:"index"`
-	Name        string            `json:"name,omitempty"`
-	Method      string            `json:"method"`
-	URL         string            `json:"url"`
-	Headers     map[string]string `json:"headers"`
-	HasBody     bool              `json:"hasBody"`
-	HasFormData bool              `json:"hasFormData"`
-	HasBodyFile bool              `json:"hasBodyFile"`
-}
-
-type ParseResponse struct {
-	Requests []struct {
-		Request     *ParsedRequestInfo `json:"request,omitempty"`
-		Diagnostics []interface{}      `json:"diagnostics"`
-	} `json:"requests"`
-	Diagnostics []interface{} `json:"diagnostics"`
-}
-
-type ExecuteResponse struct {
-	RunID   string `json:"runId"`
-	Request struct {
-		Index  int    `json:"index"`
-		Name   string `json:"name,omitempty"`
-		Method string `json:"method"`
-		URL    string `json:"url"`
-	} `json:"request"`
-	Response struct {
-		Status     int    `json:"status"`
-		StatusText string `json:"statusText"`
-		Headers    []struct {
-			Name  string `json:"name"`
-			Value string `json:"value"`
-		} `json:"headers"`
-		BodyMode  string `json:"bodyMode"`
-		Body      string `json:"body,omitempty"`
-		Encoding  string `json:"encoding"`
-		Truncated bool   `json:"truncated"`
-		BodyBytes int    `json:"bodyBytes"`
-	} `json:"response"`
-	Timing struct {
-		StartTime  int64 `json:"startTime"`
-		EndTime    int64 `json:"endTime"`
-		DurationMs int64 `json:"durationMs"`
-	} `json:"timing"`
-}
-
-type CreateSessionResponse struct {
-	SessionID string `json:"sessionId"`
-}
-
-type SessionState struct {
-	SessionID       string                 `json:"sessionId"`
-	Variables       map[string]interface{} `json:"variables"`
-	CookieCount     int                    `json:"cookieCount"`
-	CreatedAt       int64                  `json:"createdAt"`
-	LastUsedAt      int64                  `json:"lastUsedAt"`
-	SnapshotVersion int                    `json:"snapshotVersion"`
-}
-
-// Client functions
-
-func healthCheck() (*HealthResponse, error) {
-	resp, err := http.Get(baseURL + "/health")
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var health HealthResponse
-	if err := json.NewDecoder(resp.Body).Decode(&health); err != nil {
-		return nil, err
-	}
-	return &health, nil
-}
-
-func parseHTTPContent(content string) (*ParseResponse, error) {
-	payload := map[string]string{"content": content}
-	body, _ := json.Marshal(payload)
-
-	resp, err := http.Post(baseURL+"/parse", "application/json", bytes.NewBuffer(body))
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var result ParseResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
-	}
-	return &result, nil
-}
-
-func executeRequest(content string, variables map[string]interface{}) (*ExecuteResponse, error) {
-	payload := map[string]interface{}{"content": content}
-	if variables != nil {
-		payload["variables"] = variables
-	}
-	body, _ := json.Marshal(payload)
-
-	resp, err := http.Post(baseURL+"/execute", "application/json", bytes.NewBuffer(body))
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var result ExecuteResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
-	}
-	return &result, nil
-}
-
-func createSession(variables map[string]interface{}) (string, error) {
-	payload := map[string]interface{}{}
-	if variables != nil {
-		payload["variables"] = variables
-	}
-	body, _ := json.Marshal(payload)
-
-	resp, err := http.Post(baseURL+"/session", "application/json", bytes.NewBuffer(body))
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-
-	var result CreateSessionResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", err
-	}
-	return result.SessionID, nil
-}
-
-func getSession(sessionID string) (*SessionState, error) {
-	resp, err := http.Get(baseURL + "/session/" + sessionID)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var state SessionState
-	if err := json.NewDecoder(resp.Body).Decode(&state); err != nil {
-		return nil, err
-	}
-	return &state, nil
-}
-
-func deleteSession(sessionID string) error {
-	req, _ := http.NewRequest("DELETE", baseURL+"/session/"+sessionID, nil)
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
-	}
-	resp.Body.Close()
-	return nil
-}
-
-// SSE Event subscription
-func subscribeToEvents(sessionID string, handler func(event, data string)) error {
-	url := baseURL + "/event"
-	if sessionID != "" {
-		url += "?sessionId=" + sessionID
-	}
-
-	resp, err := http.Get(url)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	reader := bufio.NewReader(resp.Body)
-	var currentEvent, currentData string
-
-	for {
-		line, err := reader.ReadString('\n')
-		if err != nil {
-			if err == io.EOF {
-				break
-			}
-			return err
-		}
-
-		line = strings.TrimSpace(line)
-
-		if strings.HasPrefix(line, "event:") {
-			currentEvent = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
-		} else if strings.HasPrefix(line, "data:") {
-			currentData = strings.TrimSpace(strings.TrimPrefix(line, "data:"))
-		} else if line == "" && currentEvent != "" {
-			handler(currentEvent, currentData)
-			currentEvent = ""
-			currentData = ""
-		}
-	}
-	return nil
-}
-
 func main() {
 	fmt.Println("=== t-req Go Client Example ===")
 	fmt.Println()
 
+	ctx := context.Background()
+	client := treqclient.NewClient(baseURL)
+	// When the server is started with --auth-token, authenticate instead with:
+	//   client := treqclient.NewClient(baseURL, treqclient.WithBearerToken(authToken))
+
 	// 1. Health check
 	fmt.Println("1. Health check:")
-	health, err := healthCheck()
+	health, err := client.Health(ctx)
 	if err != nil {
 		fmt.Printf("   Error: %v\n", err)
 		return
@@ -249,7 +49,7 @@ func main() {
 GET https://jsonplaceholder.typicode.com/posts/1
 Accept: application/json
 `
-	parsed, err := parseHTTPContent(httpContent)
+	parsed, err := client.Parse(ctx, httpContent)
 	if err != nil {
 		fmt.Printf("   Error: %v\n", err)
 		return
@@ -263,7 +63,7 @@ Accept: application/json
 
 	// 3. Execute a request
 	fmt.Println("3. Execute request:")
-	result, err := executeRequest(httpContent, nil)
+	result, err := client.Execute(ctx, treqclient.ExecuteRequest{Content: httpContent})
 	if err != nil {
 		fmt.Printf("   Error: %v\n", err)
 		return
@@ -275,23 +75,31 @@ Accept: application/json
 
 	// 4. Session management
 	fmt.Println("4. Session management:")
-	sessionID, err := createSession(map[string]interface{}{
+	session, err := client.CreateSession(ctx, map[string]interface{}{
 		"baseUrl": "https://jsonplaceholder.typicode.com",
 	})
 	if err != nil {
 		fmt.Printf("   Error: %v\n", err)
 		return
 	}
-	fmt.Printf("   Created session: %s\n", sessionID)
+	fmt.Printf("   Created session: %s\n", session.SessionID)
 
-	state, err := getSession(sessionID)
+	state, err := client.GetSession(ctx, session.SessionID)
 	if err != nil {
 		fmt.Printf("   Error: %v\n", err)
 		return
 	}
 	fmt.Printf("   Variables: %v\n", state.Variables)
+	if session.XSRFToken != "" {
+		// client.SetXSRFToken already ran inside CreateSession; echoing it
+		// here just shows what gets sent as X-XSRFToken on the DELETE below.
+		fmt.Printf("   XSRF token: %s\n", client.XSRFToken())
+	}
 
-	deleteSession(sessionID)
+	if err := client.DeleteSession(ctx, session.SessionID); err != nil {
+		fmt.Printf("   Error: %v\n", err)
+		return
+	}
 	fmt.Println("   Session deleted")
 	fmt.Println()
 
@@ -301,9 +109,12 @@ Accept: application/json
 GET {{baseUrl}}/users/{{userId}}
 Accept: application/json
 `
-	result, err = executeRequest(httpWithVars, map[string]interface{}{
-		"baseUrl": "https://jsonplaceholder.typicode.com",
-		"userId":  "1",
+	result, err = client.Execute(ctx, treqclient.ExecuteRequest{
+		Content: httpWithVars,
+		Variables: map[string]interface{}{
+			"baseUrl": "https://jsonplaceholder.typicode.com",
+			"userId":  "1",
+		},
 	})
 	if err != nil {
 		fmt.Printf("   Error: %v\n", err)
@@ -313,5 +124,92 @@ Accept: application/json
 	fmt.Printf("   Request URL: %s\n", result.Request.URL)
 	fmt.Println()
 
+	// 6. Execute with streaming progress events, consuming the body
+	// incrementally instead of waiting for the whole response to buffer.
+	fmt.Println("6. Execute with streaming progress:")
+	bodyBytes := 0
+	err = client.ExecuteStream(ctx, treqclient.ExecuteRequest{Content: httpContent}, func(ev treqclient.StreamEvent) {
+		switch ev.Type {
+		case treqclient.StreamEventResponseHeaders:
+			var headers treqclient.StreamResponseHeadersPayload
+			if err := json.Unmarshal(ev.Data, &headers); err == nil {
+				fmt.Printf("   Headers: %d %s\n", headers.Status, headers.StatusText)
+			}
+		case treqclient.StreamEventResponseChunk:
+			var chunk treqclient.StreamResponseChunkPayload
+			if err := json.Unmarshal(ev.Data, &chunk); err == nil {
+				if decoded, err := base64.StdEncoding.DecodeString(chunk.Body); err == nil {
+					bodyBytes += len(decoded)
+				}
+			}
+		case treqclient.StreamEventResponseDone:
+			var done treqclient.StreamResponseDonePayload
+			if err := json.Unmarshal(ev.Data, &done); err == nil {
+				fmt.Printf("   Done: %d bytes consumed, %d reported, %dms\n", bodyBytes, done.BodyBytes, done.DurationMs)
+			}
+		}
+	})
+	if err != nil {
+		fmt.Printf("   Error: %v\n", err)
+		return
+	}
+	fmt.Println()
+
+	// 7. Chained requests: assert on the first response and extract a value
+	// from it into the session, then reference that value with {{userId}}
+	// in the next request - the way Postman/RestClient chain requests.
+	fmt.Println("7. Chained requests with assertions and extracts:")
+	chainSession, err := client.CreateSession(ctx, map[string]interface{}{
+		"baseUrl": "https://jsonplaceholder.typicode.com",
+	})
+	if err != nil {
+		fmt.Printf("   Error: %v\n", err)
+		return
+	}
+
+	userResult, err := client.Execute(ctx, treqclient.ExecuteRequest{
+		Content: `
+GET {{baseUrl}}/users/1
+Accept: application/json
+`,
+		SessionID: chainSession.SessionID,
+		Assertions: []treqclient.Assertion{
+			{Status: 200},
+			{Header: "Content-Type", Matches: "^application/json"},
+			{JSONPath: "$.id", Equals: 1},
+		},
+		Extracts: map[string]string{
+			"userId": "$.id",
+		},
+	})
+	if err != nil {
+		fmt.Printf("   Error: %v\n", err)
+		return
+	}
+	for _, a := range userResult.Assertions {
+		fmt.Printf("   Assertion %q: passed=%v %s\n", a.Name, a.Passed, a.Message)
+	}
+	fmt.Printf("   Extracted: %v\n", userResult.Extracted)
+
+	postsResult, err := client.Execute(ctx, treqclient.ExecuteRequest{
+		Content: `
+GET {{baseUrl}}/posts?userId={{userId}}
+Accept: application/json
+`,
+		SessionID: chainSession.SessionID,
+	})
+	if err != nil {
+		fmt.Printf("   Error: %v\n", err)
+		return
+	}
+	fmt.Printf("   Status: %d\n", postsResult.Response.Status)
+	fmt.Printf("   Request URL: %s\n", postsResult.Request.URL)
+
+	if err := client.DeleteSession(ctx, chainSession.SessionID); err != nil {
+		fmt.Printf("   Error: %v\n", err)
+		return
+	}
+	fmt.Println()
+
 	fmt.Println("=== Done ===")
 }
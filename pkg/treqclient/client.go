@@ -0,0 +1,290 @@
+package treqclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultTimeout = 30 * time.Second
+
+// Client is an HTTP client for a t-req server (`treq serve`).
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	headers    map[string]string
+
+	xsrfMu    sync.RWMutex
+	xsrfToken string
+}
+
+// Option configures a Client constructed via NewClient.
+type Option func(*Client)
+
+// WithTimeout sets the overall per-request timeout of the underlying http.Client.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) { c.httpClient.Timeout = d }
+}
+
+// WithHTTPClient overrides the underlying http.Client entirely, e.g. to reuse
+// a client already configured elsewhere in the caller's program.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.httpClient = hc }
+}
+
+// WithRoundTripper sets a custom http.RoundTripper on the underlying
+// http.Client, e.g. to add tracing, retries, or a custom TLS config.
+func WithRoundTripper(rt http.RoundTripper) Option {
+	return func(c *Client) { c.httpClient.Transport = rt }
+}
+
+// WithHeader attaches a header to every outgoing request, such as an
+// Authorization header.
+func WithHeader(name, value string) Option {
+	return func(c *Client) { c.headers[name] = value }
+}
+
+// WithBearerToken attaches an `Authorization: Bearer <token>` header to every
+// outgoing request, matching the server's --auth-token flag.
+func WithBearerToken(token string) Option {
+	return WithHeader("Authorization", "Bearer "+token)
+}
+
+// NewClient constructs a Client for the t-req server at baseURL, e.g.
+// "http://127.0.0.1:4096".
+func NewClient(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		headers:    map[string]string{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *Client) newRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
+	var r io.Reader
+	if body != nil {
+		buf, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("treqclient: encode request: %w", err)
+		}
+		r = bytes.NewReader(buf)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, r)
+	if err != nil {
+		return nil, fmt.Errorf("treqclient: build request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+	if method != http.MethodGet {
+		if token := c.XSRFToken(); token != "" {
+			req.Header.Set("X-XSRFToken", token)
+		}
+	}
+	return req, nil
+}
+
+// XSRFToken returns the XSRF token last captured from a CreateSession
+// response, or "" if none has been set yet.
+func (c *Client) XSRFToken() string {
+	c.xsrfMu.RLock()
+	defer c.xsrfMu.RUnlock()
+	return c.xsrfToken
+}
+
+// SetXSRFToken sets the token sent as X-XSRFToken on state-changing
+// requests (/execute, POST /session, DELETE /session/*). CreateSession
+// calls this automatically with the token the server issues; call it
+// directly when restoring a session created in an earlier process.
+func (c *Client) SetXSRFToken(token string) {
+	c.xsrfMu.Lock()
+	defer c.xsrfMu.Unlock()
+	c.xsrfToken = token
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	req, err := c.newRequest(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("treqclient: %s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return newAPIError(resp)
+	}
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("treqclient: decode response: %w", err)
+	}
+	return nil
+}
+
+// Health calls GET /health.
+func (c *Client) Health(ctx context.Context) (*HealthResponse, error) {
+	var out HealthResponse
+	if err := c.do(ctx, http.MethodGet, "/health", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Parse calls POST /parse to break .http content into individual requests
+// without executing them.
+func (c *Client) Parse(ctx context.Context, content string) (*ParseResponse, error) {
+	payload := map[string]string{"content": content}
+	var out ParseResponse
+	if err := c.do(ctx, http.MethodPost, "/parse", payload, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// runIDHeader is set by the server as soon as a run starts, before the
+// (potentially slow) response body is available, so a ctx cancelled while
+// Execute is still waiting on the body can be turned into a background
+// DELETE /run/{runID}.
+const runIDHeader = "X-Treq-Run-Id"
+
+// Execute calls POST /execute to run the request(s) in req.Content. If ctx is
+// cancelled or its deadline expires after the response headers (carrying the
+// run ID) have arrived but before the body has been read, Execute fires a
+// best-effort DELETE /run/{runID} in the background to stop the run
+// server-side. If ctx ends before the response headers arrive, the run ID is
+// not known and no DELETE is sent. Either way Execute returns ctx.Err().
+func (c *Client) Execute(ctx context.Context, req ExecuteRequest) (*ExecuteResponse, error) {
+	httpReq, err := c.newRequest(ctx, http.MethodPost, "/execute", req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("treqclient: POST /execute: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if runID := resp.Header.Get(runIDHeader); runID != "" {
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			select {
+			case <-ctx.Done():
+				c.cancelRunBestEffort(runID)
+			case <-done:
+			}
+		}()
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, newAPIError(resp)
+	}
+
+	var out ExecuteResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("treqclient: decode response: %w", err)
+	}
+	return &out, nil
+}
+
+// CancelRun calls DELETE /run/{runID} to stop an in-flight run started by
+// Execute or ExecuteStream.
+func (c *Client) CancelRun(ctx context.Context, runID string) error {
+	return c.do(ctx, http.MethodDelete, "/run/"+runID, nil, nil)
+}
+
+// cancelRunBestEffort cancels runID using a short-lived background context,
+// since the ctx that triggered the cancellation is already done.
+func (c *Client) cancelRunBestEffort(runID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = c.CancelRun(ctx, runID)
+}
+
+// CreateSession calls POST /session, seeding it with the given variables. If
+// the server returns an XSRFToken, it is stored on c and attached as
+// X-XSRFToken to subsequent state-changing calls automatically.
+func (c *Client) CreateSession(ctx context.Context, variables map[string]interface{}) (*CreateSessionResponse, error) {
+	payload := map[string]interface{}{}
+	if variables != nil {
+		payload["variables"] = variables
+	}
+	var out CreateSessionResponse
+	if err := c.do(ctx, http.MethodPost, "/session", payload, &out); err != nil {
+		return nil, err
+	}
+	if out.XSRFToken != "" {
+		c.SetXSRFToken(out.XSRFToken)
+	}
+	return &out, nil
+}
+
+// GetSession calls GET /session/{id}.
+func (c *Client) GetSession(ctx context.Context, sessionID string) (*SessionState, error) {
+	var out SessionState
+	if err := c.do(ctx, http.MethodGet, "/session/"+sessionID, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteSession calls DELETE /session/{id}.
+func (c *Client) DeleteSession(ctx context.Context, sessionID string) error {
+	return c.do(ctx, http.MethodDelete, "/session/"+sessionID, nil, nil)
+}
+
+// UpdateSession calls PUT /session/{id}, sending ifMatchVersion as an
+// If-Match header so the server can reject the update with a 409 (surfaced
+// as an *APIError) if the session's SnapshotVersion has moved on since the
+// caller last read it - e.g. because another client updated it concurrently.
+func (c *Client) UpdateSession(ctx context.Context, sessionID string, variables map[string]interface{}, ifMatchVersion int) (*SessionState, error) {
+	req, err := c.newRequest(ctx, http.MethodPut, "/session/"+sessionID, UpdateSessionRequest{Variables: variables})
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("If-Match", strconv.Itoa(ifMatchVersion))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("treqclient: PUT /session/%s: %w", sessionID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, newAPIError(resp)
+	}
+
+	var out SessionState
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("treqclient: decode response: %w", err)
+	}
+	return &out, nil
+}
@@ -0,0 +1,105 @@
+package treqclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExecuteCancelsRunOnContextCancellation(t *testing.T) {
+	const runID = "run-123"
+
+	started := make(chan struct{})
+	var cancelled atomic.Bool
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/execute":
+			w.Header().Set(runIDHeader, runID)
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			close(started)
+			<-r.Context().Done()
+		case r.Method == http.MethodDelete && r.URL.Path == "/run/"+runID:
+			cancelled.Store(true)
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.Execute(ctx, ExecuteRequest{Content: "GET http://example.com"})
+		done <- err
+	}()
+
+	<-started
+	// Give Execute's httpClient.Do a moment to finish reading the response
+	// headers before cancelling, so cancellation lands while it's blocked
+	// reading the (never-sent) body rather than racing the header read.
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Fatalf("Execute error = %v, want context.Canceled", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Execute did not return after ctx cancellation")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !cancelled.Load() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !cancelled.Load() {
+		t.Error("DELETE /run/{runID} was never called after ctx cancellation")
+	}
+}
+
+func TestCreateSessionCapturesAndReplaysXSRFToken(t *testing.T) {
+	const token = "xsrf-token-abc"
+
+	var gotToken string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/session":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"sessionId":"sess-1","xsrfToken":"` + token + `"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/session/sess-1":
+			gotToken = r.Header.Get("X-XSRFToken")
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+
+	session, err := client.CreateSession(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+	if client.XSRFToken() != token {
+		t.Fatalf("XSRFToken() = %q, want %q", client.XSRFToken(), token)
+	}
+
+	if err := client.DeleteSession(context.Background(), session.SessionID); err != nil {
+		t.Fatalf("DeleteSession: %v", err)
+	}
+	if gotToken != token {
+		t.Errorf("X-XSRFToken sent on DELETE = %q, want %q", gotToken, token)
+	}
+}
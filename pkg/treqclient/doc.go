@@ -0,0 +1,7 @@
+// Package treqclient is a Go SDK for the t-req HTTP API.
+//
+// It wraps the /health, /parse, /execute, /session, and /event endpoints
+// exposed by `treq serve` behind a typed Client, so downstream Go programs
+// don't need to hand-roll HTTP calls and JSON structs the way
+// examples/app/go_client.go used to.
+package treqclient
@@ -0,0 +1,43 @@
+package treqclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// APIError is returned whenever the server responds with a 4xx or 5xx status.
+// A 3xx response that the underlying http.Client does not follow is not
+// turned into an APIError. It carries the parsed error body so callers can branch on StatusCode or
+// inspect Message without re-parsing the raw body themselves.
+type APIError struct {
+	StatusCode int
+	Message    string
+	Body       []byte
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("treqclient: server returned %d: %s", e.StatusCode, e.Message)
+}
+
+func newAPIError(resp *http.Response) error {
+	body, _ := io.ReadAll(resp.Body)
+
+	var parsed struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	_ = json.Unmarshal(body, &parsed)
+
+	msg := parsed.Message
+	if msg == "" {
+		msg = parsed.Error
+	}
+	if msg == "" {
+		msg = strings.TrimSpace(string(body))
+	}
+
+	return &APIError{StatusCode: resp.StatusCode, Message: msg, Body: body}
+}
@@ -0,0 +1,129 @@
+package treqclient
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultReconnectDelay = 3 * time.Second
+
+// Event is a single Server-Sent Event received from /event.
+type Event struct {
+	ID   string
+	Name string
+	Data string
+}
+
+// Subscribe opens an SSE connection to /event (scoped to sessionID when
+// non-empty) and invokes handler for every event received. Unlike a single
+// `data:` line read until EOF, it assembles multi-line `data:` frames,
+// ignores comment lines (leading `:`), tracks `id:` for resumption, honors a
+// server-sent `retry:` field, and automatically reconnects - sending
+// Last-Event-ID on the follow-up request - after any disconnect, read error,
+// or transient server error, until ctx is cancelled. The one exception is a
+// 401/403 response, which is returned immediately since retrying won't help.
+func (c *Client) Subscribe(ctx context.Context, sessionID string, handler func(Event)) error {
+	path := "/event"
+	if sessionID != "" {
+		path += "?sessionId=" + url.QueryEscape(sessionID)
+	}
+
+	lastEventID := ""
+	retryDelay := defaultReconnectDelay
+
+	for {
+		err := c.subscribeOnce(ctx, path, &lastEventID, &retryDelay, handler)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if apiErr, ok := err.(*APIError); ok && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden) {
+			return err
+		}
+
+		select {
+		case <-time.After(retryDelay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (c *Client) subscribeOnce(ctx context.Context, path string, lastEventID *string, retryDelay *time.Duration, handler func(Event)) error {
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if *lastEventID != "" {
+		req.Header.Set("Last-Event-ID", *lastEventID)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return newAPIError(resp)
+	}
+
+	return scanSSEEvents(resp.Body, lastEventID, retryDelay, handler)
+}
+
+// scanSSEEvents decodes an SSE byte stream per the spec: multi-line `data:`
+// frames are joined with "\n", lines starting with `:` are comments and
+// ignored, `id:` updates *lastEventID for resumption, and `retry:` updates
+// *retryDelay for the caller's reconnect backoff. A blank line only
+// dispatches to handler if at least one `data:` line was seen since the last
+// dispatch - a bare `id:` keep-alive with no `data:` still updates
+// *lastEventID but does not fire handler with an empty event.
+func scanSSEEvents(r io.Reader, lastEventID *string, retryDelay *time.Duration, handler func(Event)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+
+	var ev Event
+	var dataLines []string
+	haveEvent := false
+
+	flush := func() {
+		if !haveEvent {
+			return
+		}
+		ev.Data = strings.Join(dataLines, "\n")
+		handler(ev)
+		ev = Event{}
+		dataLines = nil
+		haveEvent = false
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, ":"):
+			// Comment line (often used as a keep-alive ping) - ignored.
+		case strings.HasPrefix(line, "event:"):
+			ev.Name = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			dataLines = append(dataLines, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+			haveEvent = true
+		case strings.HasPrefix(line, "id:"):
+			ev.ID = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+			*lastEventID = ev.ID
+		case strings.HasPrefix(line, "retry:"):
+			if ms, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "retry:"))); err == nil {
+				*retryDelay = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+	flush()
+	return scanner.Err()
+}
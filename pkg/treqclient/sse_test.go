@@ -0,0 +1,175 @@
+package treqclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScanSSEEvents(t *testing.T) {
+	tests := []struct {
+		name           string
+		stream         string
+		wantEvents     []Event
+		wantLastEvent  string
+		wantRetryDelay time.Duration
+	}{
+		{
+			name:       "single data line",
+			stream:     "data: hello\n\n",
+			wantEvents: []Event{{Data: "hello"}},
+		},
+		{
+			name:       "multi-line data joined with newline",
+			stream:     "data: line one\ndata: line two\n\n",
+			wantEvents: []Event{{Data: "line one\nline two"}},
+		},
+		{
+			name:       "comment lines are ignored",
+			stream:     ": keep-alive\ndata: hello\n\n",
+			wantEvents: []Event{{Data: "hello"}},
+		},
+		{
+			name:          "event and id are attached to the dispatched event",
+			stream:        "event: progress\nid: 42\ndata: hello\n\n",
+			wantEvents:    []Event{{Name: "progress", ID: "42", Data: "hello"}},
+			wantLastEvent: "42",
+		},
+		{
+			name:          "bare id keep-alive with no data does not dispatch",
+			stream:        "id: 1\n\nid: 2\n\ndata: hello\n\n",
+			wantEvents:    []Event{{ID: "2", Data: "hello"}},
+			wantLastEvent: "2",
+		},
+		{
+			name:           "retry updates delay without dispatching",
+			stream:         "retry: 5000\n\ndata: hello\n\n",
+			wantEvents:     []Event{{Data: "hello"}},
+			wantRetryDelay: 5 * time.Second,
+		},
+		{
+			name:       "trailing event with no final blank line still flushes",
+			stream:     "data: hello",
+			wantEvents: []Event{{Data: "hello"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []Event
+			lastEventID := ""
+			retryDelay := defaultReconnectDelay
+
+			err := scanSSEEvents(strings.NewReader(tt.stream), &lastEventID, &retryDelay, func(ev Event) {
+				got = append(got, ev)
+			})
+			if err != nil {
+				t.Fatalf("scanSSEEvents: %v", err)
+			}
+
+			if len(got) != len(tt.wantEvents) {
+				t.Fatalf("got %d events, want %d: %+v", len(got), len(tt.wantEvents), got)
+			}
+			for i, ev := range got {
+				if ev != tt.wantEvents[i] {
+					t.Errorf("event %d = %+v, want %+v", i, ev, tt.wantEvents[i])
+				}
+			}
+
+			if tt.wantLastEvent != "" && lastEventID != tt.wantLastEvent {
+				t.Errorf("lastEventID = %q, want %q", lastEventID, tt.wantLastEvent)
+			}
+			if tt.wantRetryDelay != 0 && retryDelay != tt.wantRetryDelay {
+				t.Errorf("retryDelay = %v, want %v", retryDelay, tt.wantRetryDelay)
+			}
+		})
+	}
+}
+
+func TestSubscribeReconnectsOnTransientErrorButNotOn401(t *testing.T) {
+	t.Run("transient error reconnects with Last-Event-ID", func(t *testing.T) {
+		var attempt int32
+		reconnected := make(chan struct{})
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch atomic.AddInt32(&attempt, 1) {
+			case 1:
+				// A transient server error looks the same to Subscribe as a
+				// dropped connection: anything but a ctx error or a 401/403
+				// from subscribeOnce leads to a reconnect.
+				http.Error(w, "boom", http.StatusInternalServerError)
+			case 2:
+				w.Header().Set("Content-Type", "text/event-stream")
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprint(w, "retry: 10\nid: 5\ndata: hello\n\n")
+			default:
+				if got := r.Header.Get("Last-Event-ID"); got != "5" {
+					t.Errorf("Last-Event-ID on reconnect = %q, want %q", got, "5")
+				}
+				close(reconnected)
+				<-r.Context().Done()
+			}
+		}))
+		defer srv.Close()
+
+		client := NewClient(srv.URL)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		var got []string
+		done := make(chan error, 1)
+		go func() {
+			done <- client.Subscribe(ctx, "", func(ev Event) {
+				got = append(got, ev.Data)
+			})
+		}()
+
+		select {
+		case <-reconnected:
+		case <-time.After(5 * time.Second):
+			t.Fatal("subscription never reconnected past the transient error")
+		}
+		cancel()
+
+		select {
+		case err := <-done:
+			if err != context.Canceled {
+				t.Fatalf("Subscribe error = %v, want context.Canceled", err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("Subscribe did not return after ctx cancellation")
+		}
+
+		if len(got) != 1 || got[0] != "hello" {
+			t.Fatalf("got events %v, want [hello]", got)
+		}
+	})
+
+	t.Run("401 returns immediately without reconnecting", func(t *testing.T) {
+		var attempts int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&attempts, 1)
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer srv.Close()
+
+		client := NewClient(srv.URL)
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		err := client.Subscribe(ctx, "", func(Event) {})
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("Subscribe error = %v, want *APIError{StatusCode: 401}", err)
+		}
+		if n := atomic.LoadInt32(&attempts); n != 1 {
+			t.Fatalf("attempts = %d, want 1 (no reconnect after a 401)", n)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package treqclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// StreamEventType identifies the lifecycle stage a StreamEvent reports on.
+type StreamEventType string
+
+// Event types emitted on the /execute/stream SSE stream, and relayed on the
+// /event bus to any subscriber scoped to the run's session.
+const (
+	StreamEventRequestStart    StreamEventType = "request.start"
+	StreamEventDNSResolved     StreamEventType = "dns.resolved"
+	StreamEventTCPConnected    StreamEventType = "tcp.connected"
+	StreamEventTLSHandshaked   StreamEventType = "tls.handshaked"
+	StreamEventResponseHeaders StreamEventType = "response.headers"
+	StreamEventResponseChunk   StreamEventType = "response.chunk"
+	StreamEventResponseDone    StreamEventType = "response.done"
+	StreamEventAssertionResult StreamEventType = "assertion.result"
+)
+
+// StreamEvent is a single lifecycle event from ExecuteStream. Data holds the
+// raw JSON payload for Type; unmarshal it into the matching Stream*Payload
+// type below.
+type StreamEvent struct {
+	Type StreamEventType
+	Data json.RawMessage
+}
+
+// StreamResponseHeadersPayload is the Data payload of a
+// StreamEventResponseHeaders event.
+type StreamResponseHeadersPayload struct {
+	Status     int    `json:"status"`
+	StatusText string `json:"statusText"`
+	Headers    []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"headers"`
+}
+
+// StreamResponseChunkPayload is the Data payload of a
+// StreamEventResponseChunk event. Body is base64-encoded so chunks of binary
+// responses round-trip through JSON/SSE cleanly.
+type StreamResponseChunkPayload struct {
+	Body string `json:"body"`
+}
+
+// StreamResponseDonePayload is the Data payload of a StreamEventResponseDone
+// event.
+type StreamResponseDonePayload struct {
+	BodyBytes  int   `json:"bodyBytes"`
+	DurationMs int64 `json:"durationMs"`
+	Truncated  bool  `json:"truncated"`
+}
+
+// StreamAssertionResultPayload is the Data payload of a
+// StreamEventAssertionResult event.
+type StreamAssertionResultPayload struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message,omitempty"`
+}
+
+// ExecuteStream calls POST /execute/stream and invokes handler for every
+// lifecycle event of the run, as they arrive, instead of buffering the whole
+// response the way Execute does. The same events are published on the
+// session-scoped /event bus, so a concurrent Subscribe call for the run's
+// session sees them too. ExecuteStream returns once the server closes the
+// stream (normally right after a StreamEventResponseDone event) or ctx is
+// cancelled.
+func (c *Client) ExecuteStream(ctx context.Context, req ExecuteRequest, handler func(StreamEvent)) error {
+	httpReq, err := c.newRequest(ctx, http.MethodPost, "/execute/stream", req)
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("treqclient: POST /execute/stream: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return newAPIError(resp)
+	}
+
+	var lastEventID string
+	var retryDelay time.Duration
+	return scanSSEEvents(resp.Body, &lastEventID, &retryDelay, func(ev Event) {
+		handler(StreamEvent{Type: StreamEventType(ev.Name), Data: json.RawMessage(ev.Data)})
+	})
+}
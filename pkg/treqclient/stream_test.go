@@ -0,0 +1,78 @@
+package treqclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExecuteStreamDecodesEvents(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "event: response.headers\ndata: {\"status\":200,\"statusText\":\"OK\"}\n\n")
+		fmt.Fprint(w, "event: response.done\ndata: {\"bodyBytes\":5,\"durationMs\":12}\n\n")
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+
+	var got []StreamEvent
+	err := client.ExecuteStream(context.Background(), ExecuteRequest{Content: "GET http://example.com"}, func(ev StreamEvent) {
+		got = append(got, ev)
+	})
+	if err != nil {
+		t.Fatalf("ExecuteStream: %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("got %d events, want 2: %+v", len(got), got)
+	}
+
+	if got[0].Type != StreamEventResponseHeaders {
+		t.Errorf("event 0 type = %q, want %q", got[0].Type, StreamEventResponseHeaders)
+	}
+	var headers StreamResponseHeadersPayload
+	if err := json.Unmarshal(got[0].Data, &headers); err != nil {
+		t.Fatalf("unmarshal headers payload: %v", err)
+	}
+	if headers.Status != 200 || headers.StatusText != "OK" {
+		t.Errorf("headers payload = %+v, want {Status:200 StatusText:OK}", headers)
+	}
+
+	if got[1].Type != StreamEventResponseDone {
+		t.Errorf("event 1 type = %q, want %q", got[1].Type, StreamEventResponseDone)
+	}
+	var done StreamResponseDonePayload
+	if err := json.Unmarshal(got[1].Data, &done); err != nil {
+		t.Fatalf("unmarshal done payload: %v", err)
+	}
+	if done.BodyBytes != 5 || done.DurationMs != 12 {
+		t.Errorf("done payload = %+v, want {BodyBytes:5 DurationMs:12}", done)
+	}
+}
+
+func TestExecuteStreamSurfacesErrorStatusAsAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"message":"bad content"}`)
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+
+	err := client.ExecuteStream(context.Background(), ExecuteRequest{Content: "not a request"}, func(StreamEvent) {})
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("ExecuteStream error = %v, want *APIError{StatusCode: 400}", err)
+	}
+	if apiErr.Message != "bad content" {
+		t.Errorf("APIError.Message = %q, want %q", apiErr.Message, "bad content")
+	}
+}
@@ -0,0 +1,147 @@
+package treqclient
+
+// HealthResponse is the result of a Health call.
+type HealthResponse struct {
+	Healthy bool   `json:"healthy"`
+	Version string `json:"version"`
+}
+
+// ParsedRequestInfo describes a single request parsed out of .http content.
+type ParsedRequestInfo struct {
+	Index       int               `json:"index"`
+	Name        string            `json:"name,omitempty"`
+	Method      string            `json:"method"`
+	URL         string            `json:"url"`
+	Headers     map[string]string `json:"headers"`
+	HasBody     bool              `json:"hasBody"`
+	HasFormData bool              `json:"hasFormData"`
+	HasBodyFile bool              `json:"hasBodyFile"`
+}
+
+// ParseResponse is the result of a Parse call.
+type ParseResponse struct {
+	Requests []struct {
+		Request     *ParsedRequestInfo `json:"request,omitempty"`
+		Diagnostics []interface{}      `json:"diagnostics"`
+	} `json:"requests"`
+	Diagnostics []interface{} `json:"diagnostics"`
+}
+
+// ExecuteRequest is the payload accepted by Execute.
+type ExecuteRequest struct {
+	Content   string                 `json:"content"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+
+	// SessionID ties this run to a session, so Extracts lands in that
+	// session's variables for later requests to pick up via {{name}}
+	// substitution, and Variables is layered on top of the session's own.
+	SessionID string `json:"sessionId,omitempty"`
+
+	// Assertions are checked against the response; results come back in
+	// ExecuteResponse.Assertions. Extracts pulls values out of the response
+	// into ExecuteResponse.Extracted, and - when SessionID is set - into
+	// that session's variables.
+	Assertions []Assertion       `json:"assertions,omitempty"`
+	Extracts   map[string]string `json:"extracts,omitempty"`
+
+	// TimeoutMs bounds the whole run; ConnectTimeoutMs, TLSTimeoutMs, and
+	// ReadTimeoutMs bound the connect, TLS handshake, and body-read phases
+	// individually. Zero means no explicit bound for that phase.
+	TimeoutMs        int `json:"timeoutMs,omitempty"`
+	ConnectTimeoutMs int `json:"connectTimeoutMs,omitempty"`
+	TLSTimeoutMs     int `json:"tlsTimeoutMs,omitempty"`
+	ReadTimeoutMs    int `json:"readTimeoutMs,omitempty"`
+}
+
+// Assertion checks one property of the response. Exactly one of JSONPath,
+// Header, or Status should be set to select what's being checked. A
+// JSONPath or Header assertion also sets exactly one of Equals or Matches to
+// select the comparison; a Status assertion compares the status code itself:
+//
+//	{JSONPath: "$.id", Equals: 1}
+//	{Header: "Content-Type", Matches: "^application/json"}
+//	{Status: 200}
+type Assertion struct {
+	JSONPath string      `json:"jsonPath,omitempty"`
+	Header   string      `json:"header,omitempty"`
+	Status   int         `json:"status,omitempty"`
+	Equals   interface{} `json:"equals,omitempty"`
+	Matches  string      `json:"matches,omitempty"`
+}
+
+// AssertionResult is the outcome of checking one Assertion.
+type AssertionResult struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message,omitempty"`
+}
+
+// ExecuteResponse is the result of an Execute call.
+type ExecuteResponse struct {
+	RunID   string `json:"runId"`
+	Request struct {
+		Index  int    `json:"index"`
+		Name   string `json:"name,omitempty"`
+		Method string `json:"method"`
+		URL    string `json:"url"`
+	} `json:"request"`
+	Response struct {
+		Status     int    `json:"status"`
+		StatusText string `json:"statusText"`
+		Headers    []struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"headers"`
+		BodyMode  string `json:"bodyMode"`
+		Body      string `json:"body,omitempty"`
+		Encoding  string `json:"encoding"`
+		Truncated bool   `json:"truncated"`
+		BodyBytes int    `json:"bodyBytes"`
+	} `json:"response"`
+	Timing struct {
+		StartTime  int64 `json:"startTime"`
+		EndTime    int64 `json:"endTime"`
+		DurationMs int64 `json:"durationMs"`
+	} `json:"timing"`
+
+	// Cancelled is true if the run was stopped by a DELETE /run/{runId}
+	// call, such as a CancelRun from another goroutine (when Execute's own
+	// ctx is cancelled it returns ctx.Err() and no ExecuteResponse), and
+	// TimedOut is true if it was stopped by one of the
+	// *TimeoutMs deadlines in ExecuteRequest. Response is partial in both
+	// cases.
+	Cancelled bool `json:"cancelled,omitempty"`
+	TimedOut  bool `json:"timedOut,omitempty"`
+
+	// Assertions holds one result per entry in ExecuteRequest.Assertions,
+	// in order. Extracted holds one value per key in
+	// ExecuteRequest.Extracts, pulled out of the response.
+	Assertions []AssertionResult      `json:"assertions,omitempty"`
+	Extracted  map[string]interface{} `json:"extracted,omitempty"`
+}
+
+// CreateSessionResponse is the result of a CreateSession call.
+type CreateSessionResponse struct {
+	SessionID string `json:"sessionId"`
+
+	// XSRFToken, when the server has XSRF protection enabled, must be sent
+	// back as X-XSRFToken on subsequent state-changing calls. Client
+	// methods do this automatically once CreateSession returns one; see
+	// Client.XSRFToken and Client.SetXSRFToken.
+	XSRFToken string `json:"xsrfToken,omitempty"`
+}
+
+// UpdateSessionRequest is the payload accepted by UpdateSession.
+type UpdateSessionRequest struct {
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+// SessionState is the result of a GetSession call.
+type SessionState struct {
+	SessionID       string                 `json:"sessionId"`
+	Variables       map[string]interface{} `json:"variables"`
+	CookieCount     int                    `json:"cookieCount"`
+	CreatedAt       int64                  `json:"createdAt"`
+	LastUsedAt      int64                  `json:"lastUsedAt"`
+	SnapshotVersion int                    `json:"snapshotVersion"`
+}